@@ -0,0 +1,97 @@
+// Package auth issues and verifies the JWT bearer tokens used to
+// authenticate requests, and provides the Echo middleware that enforces
+// them.
+package auth
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/labstack/echo/v4"
+)
+
+// ErrInvalidToken is returned by Parse when a token is malformed, expired,
+// or signed with the wrong key.
+var ErrInvalidToken = errors.New("auth: invalid token")
+
+// contextKey is the echo.Context key the authenticated user ID is stored
+// under.
+const contextKey = "userID"
+
+// Claims are the custom JWT claims issued for an authenticated user.
+type Claims struct {
+	UserID string `json:"user_id"`
+	jwt.RegisteredClaims
+}
+
+// TokenIssuer issues and verifies signed JWT bearer tokens.
+type TokenIssuer struct {
+	secret []byte
+	ttl    time.Duration
+}
+
+// NewTokenIssuer creates a TokenIssuer that signs tokens with secret and
+// sets them to expire after ttl.
+func NewTokenIssuer(secret string, ttl time.Duration) *TokenIssuer {
+	return &TokenIssuer{secret: []byte(secret), ttl: ttl}
+}
+
+// Issue creates a signed token asserting the given user ID.
+func (i *TokenIssuer) Issue(userID string) (string, error) {
+	claims := &Claims{
+		UserID: userID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(i.ttl)),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+		},
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString(i.secret)
+}
+
+// Parse verifies a signed token and returns its claims.
+func (i *TokenIssuer) Parse(tokenString string) (*Claims, error) {
+	claims := &Claims{}
+	token, err := jwt.ParseWithClaims(tokenString, claims, func(t *jwt.Token) (interface{}, error) {
+		return i.secret, nil
+	})
+	if err != nil || !token.Valid {
+		return nil, ErrInvalidToken
+	}
+
+	return claims, nil
+}
+
+// Middleware returns an Echo middleware that requires a valid
+// "Authorization: Bearer <token>" header, making the authenticated user ID
+// available to handlers via UserID.
+func Middleware(issuer *TokenIssuer) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			header := c.Request().Header.Get(echo.HeaderAuthorization)
+			token, ok := strings.CutPrefix(header, "Bearer ")
+			if !ok || token == "" {
+				return echo.NewHTTPError(http.StatusUnauthorized, "missing bearer token")
+			}
+
+			claims, err := issuer.Parse(token)
+			if err != nil {
+				return echo.NewHTTPError(http.StatusUnauthorized, "invalid or expired token")
+			}
+
+			c.Set(contextKey, claims.UserID)
+			return next(c)
+		}
+	}
+}
+
+// UserID returns the authenticated user ID stored in c by Middleware, or ""
+// if the request was not authenticated.
+func UserID(c echo.Context) string {
+	userID, _ := c.Get(contextKey).(string)
+	return userID
+}
@@ -0,0 +1,136 @@
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTokenIssuerIssueAndParse(t *testing.T) {
+	issuer := NewTokenIssuer("test-secret", time.Hour)
+
+	token, err := issuer.Issue("jane")
+	assert.NoError(t, err)
+	assert.NotEmpty(t, token)
+
+	claims, err := issuer.Parse(token)
+	assert.NoError(t, err)
+	assert.Equal(t, "jane", claims.UserID)
+}
+
+func TestTokenIssuerParseExpiredToken(t *testing.T) {
+	issuer := NewTokenIssuer("test-secret", -time.Hour)
+
+	token, err := issuer.Issue("jane")
+	assert.NoError(t, err)
+
+	_, err = issuer.Parse(token)
+	assert.ErrorIs(t, err, ErrInvalidToken)
+}
+
+func TestTokenIssuerParseWrongSecret(t *testing.T) {
+	token, err := NewTokenIssuer("test-secret", time.Hour).Issue("jane")
+	assert.NoError(t, err)
+
+	_, err = NewTokenIssuer("other-secret", time.Hour).Parse(token)
+	assert.ErrorIs(t, err, ErrInvalidToken)
+}
+
+func TestTokenIssuerParseMalformedToken(t *testing.T) {
+	issuer := NewTokenIssuer("test-secret", time.Hour)
+
+	_, err := issuer.Parse("not-a-jwt")
+	assert.ErrorIs(t, err, ErrInvalidToken)
+}
+
+func TestMiddlewareValidToken(t *testing.T) {
+	issuer := NewTokenIssuer("test-secret", time.Hour)
+	token, err := issuer.Issue("jane")
+	assert.NoError(t, err)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(echo.HeaderAuthorization, "Bearer "+token)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	var gotUserID string
+	handler := Middleware(issuer)(func(c echo.Context) error {
+		gotUserID = UserID(c)
+		return c.NoContent(http.StatusOK)
+	})
+
+	assert.NoError(t, handler(c))
+	assert.Equal(t, "jane", gotUserID)
+}
+
+func TestMiddlewareMissingAuthorizationHeader(t *testing.T) {
+	issuer := NewTokenIssuer("test-secret", time.Hour)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	handler := Middleware(issuer)(func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	})
+
+	err := handler(c)
+	var httpErr *echo.HTTPError
+	assert.ErrorAs(t, err, &httpErr)
+	assert.Equal(t, http.StatusUnauthorized, httpErr.Code)
+}
+
+func TestMiddlewareMissingBearerPrefix(t *testing.T) {
+	issuer := NewTokenIssuer("test-secret", time.Hour)
+	token, err := issuer.Issue("jane")
+	assert.NoError(t, err)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(echo.HeaderAuthorization, token)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	handler := Middleware(issuer)(func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	})
+
+	err = handler(c)
+	var httpErr *echo.HTTPError
+	assert.ErrorAs(t, err, &httpErr)
+	assert.Equal(t, http.StatusUnauthorized, httpErr.Code)
+}
+
+func TestMiddlewareInvalidToken(t *testing.T) {
+	issuer := NewTokenIssuer("test-secret", time.Hour)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(echo.HeaderAuthorization, "Bearer not-a-jwt")
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	handler := Middleware(issuer)(func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	})
+
+	err := handler(c)
+	var httpErr *echo.HTTPError
+	assert.ErrorAs(t, err, &httpErr)
+	assert.Equal(t, http.StatusUnauthorized, httpErr.Code)
+}
+
+func TestUserIDUnauthenticated(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	assert.Equal(t, "", UserID(c))
+}
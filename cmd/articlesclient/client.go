@@ -0,0 +1,150 @@
+// Command articlesclient is a typed Go client for the articles HTTP API,
+// usable both as a library (the Client type) and as a small CLI for
+// exercising it by hand.
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CreateArticleCommand mirrors the API's request body for creating an
+// article.
+type CreateArticleCommand struct {
+	Title string `json:"title"`
+	Body  string `json:"body"`
+}
+
+// ArticleReadModel mirrors the API's representation of an article.
+type ArticleReadModel struct {
+	ID      string `json:"id"`
+	Slug    string `json:"slug"`
+	Author  string `json:"author"`
+	Title   string `json:"title"`
+	Body    string `json:"body"`
+	Created string `json:"created"`
+}
+
+// ListOpts filters and paginates ListArticles.
+type ListOpts struct {
+	Query  string
+	Author string
+	Limit  int
+	Offset int
+}
+
+// Client is a typed HTTP client for the articles API.
+type Client struct {
+	baseURL string
+	token   string
+	http    *http.Client
+}
+
+// NewClient creates a Client against baseURL, authenticating requests with
+// the given bearer token. token may be empty for endpoints that don't
+// require it.
+func NewClient(baseURL, token string) *Client {
+	return &Client{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		token:   token,
+		http:    &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// CreateArticle enqueues the creation of a new article and returns the ID
+// of the command tracking it; the article itself is created
+// asynchronously.
+func (c *Client) CreateArticle(ctx context.Context, command CreateArticleCommand) (string, error) {
+	body, err := json.Marshal(command)
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/articles", bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	c.authorize(req)
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusAccepted {
+		return "", fmt.Errorf("articlesclient: create article: unexpected status %s", resp.Status)
+	}
+
+	var created struct {
+		CommandID string `json:"command_id"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&created); err != nil {
+		return "", err
+	}
+
+	return created.CommandID, nil
+}
+
+// ListArticles retrieves the articles matching opts.
+func (c *Client) ListArticles(ctx context.Context, opts ListOpts) ([]ArticleReadModel, error) {
+	u, err := url.Parse(c.baseURL + "/articles")
+	if err != nil {
+		return nil, err
+	}
+
+	query := u.Query()
+	if opts.Query != "" {
+		query.Set("q", opts.Query)
+	}
+	if opts.Author != "" {
+		query.Set("author", opts.Author)
+	}
+	if opts.Limit != 0 {
+		query.Set("limit", strconv.Itoa(opts.Limit))
+	}
+	if opts.Offset != 0 {
+		query.Set("offset", strconv.Itoa(opts.Offset))
+	}
+	u.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	c.authorize(req)
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("articlesclient: list articles: unexpected status %s", resp.Status)
+	}
+
+	var result struct {
+		Items []ArticleReadModel `json:"items"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+
+	return result.Items, nil
+}
+
+// authorize attaches the client's bearer token to req, if any.
+func (c *Client) authorize(req *http.Request) {
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+}
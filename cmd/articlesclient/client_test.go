@@ -0,0 +1,51 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClientCreateArticle(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/articles", r.URL.Path)
+		assert.Equal(t, "Bearer test-token", r.Header.Get("Authorization"))
+
+		var command CreateArticleCommand
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&command))
+		assert.Equal(t, "Hello World", command.Title)
+
+		w.WriteHeader(http.StatusAccepted)
+		json.NewEncoder(w).Encode(map[string]string{"command_id": "42"})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token")
+	commandID, err := client.CreateArticle(context.Background(), CreateArticleCommand{Title: "Hello World", Body: "Body"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "42", commandID)
+}
+
+func TestClientListArticles(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "golang", r.URL.Query().Get("q"))
+
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"items": []ArticleReadModel{{ID: "a1", Title: "Hello World"}},
+			"total": 1,
+		})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "")
+	articles, err := client.ListArticles(context.Background(), ListOpts{Query: "golang"})
+
+	assert.NoError(t, err)
+	assert.Len(t, articles, 1)
+	assert.Equal(t, "a1", articles[0].ID)
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+)
+
+func main() {
+	baseURL := os.Getenv("ARTICLES_API_URL")
+	if baseURL == "" {
+		baseURL = "http://localhost:8080"
+	}
+	client := NewClient(baseURL, os.Getenv("ARTICLES_API_TOKEN"))
+
+	if len(os.Args) < 2 {
+		log.Fatal("usage: articlesclient <list|create> [args...]")
+	}
+
+	ctx := context.Background()
+
+	switch os.Args[1] {
+	case "list":
+		var query, author string
+		if len(os.Args) > 2 {
+			query = os.Args[2]
+		}
+		if len(os.Args) > 3 {
+			author = os.Args[3]
+		}
+
+		articles, err := client.ListArticles(ctx, ListOpts{Query: query, Author: author})
+		if err != nil {
+			log.Fatal(err)
+		}
+		for _, article := range articles {
+			fmt.Printf("%s\t%s\t%s\n", article.ID, article.Author, article.Title)
+		}
+
+	case "create":
+		if len(os.Args) < 4 {
+			log.Fatal("usage: articlesclient create <title> <body>")
+		}
+
+		commandID, err := client.CreateArticle(ctx, CreateArticleCommand{Title: os.Args[2], Body: os.Args[3]})
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Println("command_id:", commandID)
+
+	default:
+		log.Fatalf("unknown command %q", os.Args[1])
+	}
+}
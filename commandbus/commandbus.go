@@ -0,0 +1,167 @@
+// Package commandbus provides an asynchronous command bus for write
+// operations. Commands are enqueued as JSON payloads and executed later by
+// a Worker, so the caller that dispatched a command does not block on its
+// side effects and can instead poll for its outcome via Status.
+package commandbus
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"time"
+)
+
+// ErrUnknownCommand is returned by Dispatch when no handler has been
+// registered for the given command type.
+var ErrUnknownCommand = errors.New("commandbus: unknown command type")
+
+// ErrNotFound is returned by Status when no command with the given ID
+// exists.
+var ErrNotFound = errors.New("commandbus: command not found")
+
+// Status describes where a dispatched command is in its lifecycle.
+type Status string
+
+// Possible Job statuses.
+const (
+	StatusPending    Status = "pending"
+	StatusProcessing Status = "processing"
+	StatusCompleted  Status = "completed"
+	StatusFailed     Status = "failed"
+	// StatusDead marks a command that exhausted its retry attempts. It is
+	// left in place for inspection rather than retried again.
+	StatusDead Status = "dead"
+)
+
+// CommandID identifies a dispatched command.
+type CommandID string
+
+// Handler executes a command's decoded payload. A returned error causes the
+// command to be retried with backoff, up to the worker's attempt limit.
+type Handler func(ctx context.Context, payload []byte) error
+
+// Job represents a dispatched command and its current execution state.
+type Job struct {
+	ID        CommandID
+	Type      string
+	Payload   []byte
+	Status    Status
+	Attempts  int
+	LastError string
+	Created   time.Time
+}
+
+// Bus dispatches commands for asynchronous execution and reports on their
+// progress.
+type Bus interface {
+	// Register associates a command type with the handler that executes it.
+	// It is not safe to call concurrently with Dispatch and is intended to
+	// be called during setup, before the bus serves any traffic.
+	Register(commandType string, handler Handler)
+
+	// Dispatch enqueues a command for execution and returns its ID
+	// immediately, without waiting for it to run.
+	Dispatch(ctx context.Context, commandType string, payload interface{}) (CommandID, error)
+
+	// Status returns the current state of a previously dispatched command,
+	// or ErrNotFound if id is unknown.
+	Status(ctx context.Context, id CommandID) (*Job, error)
+}
+
+// Schema is the DDL for the commands table backing PostgresBus. Callers run
+// it once during provisioning; it is idempotent.
+const Schema = `
+CREATE TABLE IF NOT EXISTS commands (
+	id BIGSERIAL PRIMARY KEY,
+	type TEXT NOT NULL,
+	payload JSONB NOT NULL,
+	status TEXT NOT NULL DEFAULT 'pending',
+	attempts INT NOT NULL DEFAULT 0,
+	last_error TEXT NOT NULL DEFAULT '',
+	available_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+	created TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+`
+
+// PostgresBus is a Bus backed by a PostgreSQL "commands" table, polled by
+// one or more Workers using SELECT ... FOR UPDATE SKIP LOCKED so that
+// multiple workers can share the queue without double-processing a job.
+type PostgresBus struct {
+	db       *sql.DB
+	handlers map[string]Handler
+}
+
+// NewPostgresBus creates a PostgresBus using the given database handle.
+func NewPostgresBus(db *sql.DB) *PostgresBus {
+	return &PostgresBus{db: db, handlers: make(map[string]Handler)}
+}
+
+// Migrate creates the commands table if it does not already exist.
+func (b *PostgresBus) Migrate(ctx context.Context) error {
+	_, err := b.db.ExecContext(ctx, Schema)
+	return err
+}
+
+// Register implements Bus.
+func (b *PostgresBus) Register(commandType string, handler Handler) {
+	b.handlers[commandType] = handler
+}
+
+// Dispatch implements Bus.
+func (b *PostgresBus) Dispatch(ctx context.Context, commandType string, payload interface{}) (CommandID, error) {
+	if _, ok := b.handlers[commandType]; !ok {
+		return "", ErrUnknownCommand
+	}
+
+	encoded, err := json.Marshal(payload)
+	if err != nil {
+		return "", err
+	}
+
+	var id int64
+	err = b.db.QueryRowContext(ctx,
+		"INSERT INTO commands(type, payload) VALUES($1, $2) RETURNING id",
+		commandType, encoded,
+	).Scan(&id)
+	if err != nil {
+		return "", err
+	}
+
+	return commandIDFromInt(id), nil
+}
+
+// Status implements Bus.
+func (b *PostgresBus) Status(ctx context.Context, id CommandID) (*Job, error) {
+	n, err := commandIDToInt(id)
+	if err != nil {
+		return nil, ErrNotFound
+	}
+
+	var job Job
+	var status string
+	err = b.db.QueryRowContext(ctx,
+		"SELECT id, type, payload, status, attempts, last_error, created FROM commands WHERE id = $1", n,
+	).Scan(&n, &job.Type, &job.Payload, &status, &job.Attempts, &job.LastError, &job.Created)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	job.ID = commandIDFromInt(n)
+	job.Status = Status(status)
+	return &job, nil
+}
+
+// commandIDFromInt renders a commands.id row as a CommandID.
+func commandIDFromInt(id int64) CommandID {
+	return CommandID(strconv.FormatInt(id, 10))
+}
+
+// commandIDToInt parses a CommandID back into a commands.id row.
+func commandIDToInt(id CommandID) (int64, error) {
+	return strconv.ParseInt(string(id), 10, 64)
+}
@@ -0,0 +1,82 @@
+package commandbus
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// SyncBus is a Bus that executes commands immediately, in-process, on the
+// calling goroutine. It implements the same interface as PostgresBus so
+// callers can depend on Bus without caring which is wired up, which makes it
+// a convenient stand-in in tests that don't need a real job queue.
+type SyncBus struct {
+	mu       sync.Mutex
+	handlers map[string]Handler
+	jobs     map[CommandID]*Job
+	nextID   int64
+}
+
+// NewSyncBus creates an empty SyncBus.
+func NewSyncBus() *SyncBus {
+	return &SyncBus{
+		handlers: make(map[string]Handler),
+		jobs:     make(map[CommandID]*Job),
+	}
+}
+
+// Register implements Bus.
+func (b *SyncBus) Register(commandType string, handler Handler) {
+	b.handlers[commandType] = handler
+}
+
+// Dispatch implements Bus, running the registered handler before returning.
+func (b *SyncBus) Dispatch(ctx context.Context, commandType string, payload interface{}) (CommandID, error) {
+	handler, ok := b.handlers[commandType]
+	if !ok {
+		return "", ErrUnknownCommand
+	}
+
+	encoded, err := json.Marshal(payload)
+	if err != nil {
+		return "", err
+	}
+
+	b.mu.Lock()
+	b.nextID++
+	id := CommandID(strconv.FormatInt(b.nextID, 10))
+	job := &Job{ID: id, Type: commandType, Payload: encoded, Status: StatusProcessing, Created: time.Now()}
+	b.jobs[id] = job
+	b.mu.Unlock()
+
+	if err := handler(ctx, encoded); err != nil {
+		b.mu.Lock()
+		job.Status = StatusFailed
+		job.Attempts = 1
+		job.LastError = err.Error()
+		b.mu.Unlock()
+		return id, nil
+	}
+
+	b.mu.Lock()
+	job.Status = StatusCompleted
+	job.Attempts = 1
+	b.mu.Unlock()
+
+	return id, nil
+}
+
+// Status implements Bus.
+func (b *SyncBus) Status(_ context.Context, id CommandID) (*Job, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	job, ok := b.jobs[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	copied := *job
+	return &copied, nil
+}
@@ -0,0 +1,57 @@
+package commandbus
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSyncBusDispatchRunsHandlerAndRecordsStatus(t *testing.T) {
+	bus := NewSyncBus()
+
+	var received string
+	bus.Register("greet", func(_ context.Context, payload []byte) error {
+		received = string(payload)
+		return nil
+	})
+
+	id, err := bus.Dispatch(context.Background(), "greet", "hello")
+	assert.NoError(t, err)
+	assert.Equal(t, `"hello"`, received)
+
+	job, err := bus.Status(context.Background(), id)
+	assert.NoError(t, err)
+	assert.Equal(t, StatusCompleted, job.Status)
+	assert.Equal(t, 1, job.Attempts)
+}
+
+func TestSyncBusDispatchRecordsHandlerFailure(t *testing.T) {
+	bus := NewSyncBus()
+	bus.Register("fail", func(context.Context, []byte) error {
+		return errors.New("boom")
+	})
+
+	id, err := bus.Dispatch(context.Background(), "fail", nil)
+	assert.NoError(t, err)
+
+	job, err := bus.Status(context.Background(), id)
+	assert.NoError(t, err)
+	assert.Equal(t, StatusFailed, job.Status)
+	assert.Equal(t, "boom", job.LastError)
+}
+
+func TestSyncBusDispatchUnknownCommand(t *testing.T) {
+	bus := NewSyncBus()
+
+	_, err := bus.Dispatch(context.Background(), "does-not-exist", nil)
+	assert.ErrorIs(t, err, ErrUnknownCommand)
+}
+
+func TestSyncBusStatusNotFound(t *testing.T) {
+	bus := NewSyncBus()
+
+	_, err := bus.Status(context.Background(), CommandID("1"))
+	assert.ErrorIs(t, err, ErrNotFound)
+}
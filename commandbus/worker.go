@@ -0,0 +1,130 @@
+package commandbus
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+	"math"
+	"time"
+)
+
+// maxAttempts is the number of times a failing command is retried before it
+// is moved to StatusDead.
+const maxAttempts = 5
+
+// Worker polls a PostgresBus for runnable commands and executes them using
+// the handlers registered on the bus.
+type Worker struct {
+	bus          *PostgresBus
+	pollInterval time.Duration
+}
+
+// NewWorker creates a Worker that polls bus every pollInterval.
+func NewWorker(bus *PostgresBus, pollInterval time.Duration) *Worker {
+	return &Worker{bus: bus, pollInterval: pollInterval}
+}
+
+// Run polls for and executes commands until ctx is cancelled.
+func (w *Worker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			for {
+				ran, err := w.runOnce(ctx)
+				if err != nil {
+					log.Printf("commandbus: worker: %v", err)
+				}
+				if !ran {
+					break
+				}
+			}
+		}
+	}
+}
+
+// runOnce claims and executes a single due command, reporting whether one
+// was found.
+func (w *Worker) runOnce(ctx context.Context) (bool, error) {
+	tx, err := w.bus.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	var id int64
+	var commandType string
+	var payload []byte
+	var attempts int
+	err = tx.QueryRowContext(ctx,
+		`SELECT id, type, payload, attempts FROM commands
+		 WHERE status = $1 AND available_at <= now()
+		 ORDER BY id ASC
+		 FOR UPDATE SKIP LOCKED
+		 LIMIT 1`,
+		StatusPending,
+	).Scan(&id, &commandType, &payload, &attempts)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	if _, err := tx.ExecContext(ctx,
+		"UPDATE commands SET status = $1 WHERE id = $2", StatusProcessing, id,
+	); err != nil {
+		return false, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, err
+	}
+
+	handler, ok := w.bus.handlers[commandType]
+	if !ok {
+		w.fail(ctx, id, attempts, ErrUnknownCommand)
+		return true, nil
+	}
+
+	if err := handler(ctx, payload); err != nil {
+		w.fail(ctx, id, attempts, err)
+		return true, nil
+	}
+
+	_, err = w.bus.db.ExecContext(ctx,
+		"UPDATE commands SET status = $1 WHERE id = $2", StatusCompleted, id,
+	)
+	return true, err
+}
+
+// fail records a handler error against a command, either scheduling a
+// backed-off retry or moving it to StatusDead once maxAttempts is reached.
+func (w *Worker) fail(ctx context.Context, id int64, attempts int, cause error) {
+	attempts++
+
+	status := StatusPending
+	availableAt := time.Now().Add(backoff(attempts))
+	if attempts >= maxAttempts {
+		status = StatusDead
+	}
+
+	if _, err := w.bus.db.ExecContext(ctx,
+		`UPDATE commands SET status = $1, attempts = $2, last_error = $3, available_at = $4
+		 WHERE id = $5`,
+		status, attempts, cause.Error(), availableAt, id,
+	); err != nil {
+		log.Printf("commandbus: worker: recording failure for command %d: %v", id, err)
+	}
+}
+
+// backoff returns an exponential delay before a command's next retry.
+func backoff(attempts int) time.Duration {
+	seconds := math.Pow(2, float64(attempts))
+	return time.Duration(seconds) * time.Second
+}
@@ -0,0 +1,338 @@
+// Package docs wraps Echo route registration so each route's request and
+// response shapes are captured as they're registered, then exposes the
+// accumulated routes as an OpenAPI 3 specification and a Swagger UI,
+// without requiring handlers to be annotated separately from where they're
+// wired up.
+package docs
+
+import (
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// Document is the root of an OpenAPI 3 specification.
+type Document struct {
+	OpenAPI    string              `json:"openapi"`
+	Info       Info                `json:"info"`
+	Paths      map[string]PathItem `json:"paths"`
+	Components Components          `json:"components"`
+}
+
+// Info describes the documented API.
+type Info struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+// PathItem maps a lowercase HTTP method ("get", "post", ...) to the
+// operation served at that path for that method.
+type PathItem map[string]Operation
+
+// Operation describes a single HTTP method on a path.
+type Operation struct {
+	Summary     string              `json:"summary,omitempty"`
+	Parameters  []Parameter         `json:"parameters,omitempty"`
+	RequestBody *RequestBody        `json:"requestBody,omitempty"`
+	Responses   map[string]Response `json:"responses"`
+}
+
+// Parameter describes a path or query parameter.
+type Parameter struct {
+	Name     string `json:"name"`
+	In       string `json:"in"`
+	Required bool   `json:"required,omitempty"`
+	Schema   Schema `json:"schema"`
+}
+
+// RequestBody describes the JSON body accepted by an operation.
+type RequestBody struct {
+	Required bool                 `json:"required"`
+	Content  map[string]MediaType `json:"content"`
+}
+
+// Response describes a single possible response of an operation.
+type Response struct {
+	Description string               `json:"description"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+// MediaType pairs a content type with the schema of its body.
+type MediaType struct {
+	Schema Schema `json:"schema"`
+}
+
+// Components holds the named schemas referenced by $ref elsewhere in the
+// document.
+type Components struct {
+	Schemas map[string]Schema `json:"schemas"`
+}
+
+// Schema is a (deliberately small) subset of JSON Schema, just enough to
+// describe the structs this API sends and receives.
+type Schema struct {
+	Ref        string            `json:"$ref,omitempty"`
+	Type       string            `json:"type,omitempty"`
+	Format     string            `json:"format,omitempty"`
+	Properties map[string]Schema `json:"properties,omitempty"`
+	Items      *Schema           `json:"items,omitempty"`
+}
+
+// Registrar wraps an *echo.Echo, recording an OpenAPI operation for every
+// route registered through it.
+type Registrar struct {
+	e   *echo.Echo
+	doc *Document
+}
+
+// NewRegistrar creates a Registrar that registers routes on e and
+// accumulates them into a document titled title, versioned version.
+func NewRegistrar(e *echo.Echo, title, version string) *Registrar {
+	return &Registrar{
+		e: e,
+		doc: &Document{
+			OpenAPI: "3.0.3",
+			Info:    Info{Title: title, Version: version},
+			Paths:   make(map[string]PathItem),
+			Components: Components{
+				Schemas: make(map[string]Schema),
+			},
+		},
+	}
+}
+
+// RouteOption customizes the Operation recorded for a route.
+type RouteOption func(*Registrar, *Operation)
+
+// Summary sets a route's one-line description.
+func Summary(summary string) RouteOption {
+	return func(_ *Registrar, op *Operation) {
+		op.Summary = summary
+	}
+}
+
+// PathParam declares a required path parameter, e.g. the ":id" in
+// "/articles/:id".
+func PathParam(name string) RouteOption {
+	return func(_ *Registrar, op *Operation) {
+		op.Parameters = append(op.Parameters, Parameter{Name: name, In: "path", Required: true, Schema: Schema{Type: "string"}})
+	}
+}
+
+// Query declares the route's query parameters, derived from the exported
+// fields of sample that carry a "query" tag.
+func Query(sample interface{}) RouteOption {
+	return func(r *Registrar, op *Operation) {
+		t := reflect.TypeOf(sample)
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			name := field.Tag.Get("query")
+			if name == "" {
+				continue
+			}
+			op.Parameters = append(op.Parameters, Parameter{Name: name, In: "query", Schema: r.schemaOf(field.Type)})
+		}
+	}
+}
+
+// Body declares the route's JSON request body, derived from sample.
+func Body(sample interface{}) RouteOption {
+	return func(r *Registrar, op *Operation) {
+		op.RequestBody = &RequestBody{
+			Required: true,
+			Content:  map[string]MediaType{"application/json": {Schema: r.schemaOf(reflect.TypeOf(sample))}},
+		}
+	}
+}
+
+// Responds declares one of the route's possible JSON responses, derived
+// from sample.
+func Responds(status int, sample interface{}) RouteOption {
+	return func(r *Registrar, op *Operation) {
+		if op.Responses == nil {
+			op.Responses = make(map[string]Response)
+		}
+		op.Responses[strconv.Itoa(status)] = Response{
+			Description: http.StatusText(status),
+			Content:     map[string]MediaType{"application/json": {Schema: r.schemaOf(reflect.TypeOf(sample))}},
+		}
+	}
+}
+
+// GET registers a GET route on the underlying Echo instance and records its
+// operation.
+func (r *Registrar) GET(path string, h echo.HandlerFunc, opts ...RouteOption) {
+	r.e.GET(path, h)
+	r.record(http.MethodGet, path, opts)
+}
+
+// POST registers a POST route on the underlying Echo instance and records
+// its operation.
+func (r *Registrar) POST(path string, h echo.HandlerFunc, opts ...RouteOption) {
+	r.e.POST(path, h)
+	r.record(http.MethodPost, path, opts)
+}
+
+// Group returns a Group that registers routes under prefix, running
+// middleware on each of them.
+func (r *Registrar) Group(prefix string, middleware ...echo.MiddlewareFunc) *Group {
+	return &Group{registrar: r, prefix: prefix, echoGroup: r.e.Group(prefix, middleware...)}
+}
+
+// record builds and stores the Operation for method and path from opts.
+func (r *Registrar) record(method, path string, opts []RouteOption) {
+	op := Operation{Responses: map[string]Response{}}
+	for _, opt := range opts {
+		opt(r, &op)
+	}
+
+	item, ok := r.doc.Paths[openAPIPath(path)]
+	if !ok {
+		item = PathItem{}
+	}
+	item[strings.ToLower(method)] = op
+	r.doc.Paths[openAPIPath(path)] = item
+}
+
+// Group registers routes under a path prefix, mirroring echo.Group.
+type Group struct {
+	registrar *Registrar
+	prefix    string
+	echoGroup *echo.Group
+}
+
+// POST registers a POST route under the group's prefix.
+func (g *Group) POST(path string, h echo.HandlerFunc, opts ...RouteOption) {
+	g.echoGroup.POST(path, h)
+	g.registrar.record(http.MethodPost, g.prefix+path, opts)
+}
+
+// PUT registers a PUT route under the group's prefix.
+func (g *Group) PUT(path string, h echo.HandlerFunc, opts ...RouteOption) {
+	g.echoGroup.PUT(path, h)
+	g.registrar.record(http.MethodPut, g.prefix+path, opts)
+}
+
+// DELETE registers a DELETE route under the group's prefix.
+func (g *Group) DELETE(path string, h echo.HandlerFunc, opts ...RouteOption) {
+	g.echoGroup.DELETE(path, h)
+	g.registrar.record(http.MethodDelete, g.prefix+path, opts)
+}
+
+// openAPIPath rewrites an Echo path, e.g. "/articles/:id", into OpenAPI's
+// brace syntax, e.g. "/articles/{id}".
+func openAPIPath(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, ":") {
+			segments[i] = "{" + segment[1:] + "}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// schemaOf derives a Schema for t, registering struct types as named
+// components referenced by $ref.
+func (r *Registrar) schemaOf(t reflect.Type) Schema {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return Schema{Type: "string"}
+	case reflect.Bool:
+		return Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return Schema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return Schema{Type: "number"}
+	case reflect.Slice, reflect.Array:
+		items := r.schemaOf(t.Elem())
+		return Schema{Type: "array", Items: &items}
+	case reflect.Map, reflect.Interface:
+		return Schema{Type: "object"}
+	case reflect.Struct:
+		if t == reflect.TypeOf(time.Time{}) {
+			return Schema{Type: "string", Format: "date-time"}
+		}
+		// Anonymous structs (e.g. one-off response shapes declared inline at
+		// the call site) have no type name to key a component on, so they're
+		// described in place rather than registered and $ref'd.
+		if t.Name() == "" {
+			return r.objectSchema(t)
+		}
+		return Schema{Ref: "#/components/schemas/" + r.registerStruct(t)}
+	default:
+		return Schema{}
+	}
+}
+
+// registerStruct records t's JSON shape under Components.Schemas, keyed by
+// its type name, and returns that name.
+func (r *Registrar) registerStruct(t reflect.Type) string {
+	name := t.Name()
+	if _, ok := r.doc.Components.Schemas[name]; ok {
+		return name
+	}
+
+	r.doc.Components.Schemas[name] = r.objectSchema(t)
+	return name
+}
+
+// objectSchema builds the inline object Schema describing struct type t's
+// exported, JSON-tagged fields.
+func (r *Registrar) objectSchema(t reflect.Type) Schema {
+	properties := make(map[string]Schema)
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" {
+			continue // unexported
+		}
+		jsonName, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if jsonName == "-" {
+			continue
+		}
+		if jsonName == "" {
+			jsonName = field.Name
+		}
+		properties[jsonName] = r.schemaOf(field.Type)
+	}
+
+	return Schema{Type: "object", Properties: properties}
+}
+
+// SpecHandler serves the accumulated OpenAPI document as JSON.
+func (r *Registrar) SpecHandler() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		return c.JSON(http.StatusOK, r.doc)
+	}
+}
+
+// UIHandler serves a Swagger UI page that loads the spec from SpecHandler.
+func (r *Registrar) UIHandler() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		return c.HTML(http.StatusOK, swaggerUIPage)
+	}
+}
+
+const swaggerUIPage = `<!DOCTYPE html>
+<html>
+<head>
+	<title>API Documentation</title>
+	<link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist/swagger-ui.css" />
+</head>
+<body>
+	<div id="swagger-ui"></div>
+	<script src="https://unpkg.com/swagger-ui-dist/swagger-ui-bundle.js"></script>
+	<script>
+		window.onload = () => SwaggerUIBundle({url: "/openapi.json", dom_id: "#swagger-ui"});
+	</script>
+</body>
+</html>
+`
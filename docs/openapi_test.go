@@ -0,0 +1,75 @@
+package docs
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+type samplePayload struct {
+	Title string `json:"title"`
+	Tags  []int  `json:"tags"`
+}
+
+func TestRegistrarRecordsRoutesAndSchemas(t *testing.T) {
+	e := echo.New()
+	reg := NewRegistrar(e, "Test API", "0.1.0")
+
+	noop := func(c echo.Context) error { return c.NoContent(http.StatusOK) }
+
+	reg.GET("/items/:id", noop, Summary("Get an item"), PathParam("id"), Responds(http.StatusOK, samplePayload{}))
+	reg.POST("/items", noop, Summary("Create an item"), Body(samplePayload{}))
+
+	protected := reg.Group("/admin")
+	protected.DELETE("/items/:id", noop, PathParam("id"))
+
+	assert.Contains(t, reg.doc.Paths, "/items/{id}")
+	assert.Contains(t, reg.doc.Paths["/items/{id}"], "get")
+	assert.Contains(t, reg.doc.Paths, "/items")
+	assert.Contains(t, reg.doc.Paths["/items"], "post")
+	assert.Contains(t, reg.doc.Paths, "/admin/items/{id}")
+	assert.Contains(t, reg.doc.Paths["/admin/items/{id}"], "delete")
+
+	schema, ok := reg.doc.Components.Schemas["samplePayload"]
+	assert.True(t, ok)
+	assert.Equal(t, "string", schema.Properties["title"].Type)
+	assert.Equal(t, "array", schema.Properties["tags"].Type)
+	assert.Equal(t, "integer", schema.Properties["tags"].Items.Type)
+}
+
+func TestRegistrarAnonymousResponseSchemasDontCollide(t *testing.T) {
+	e := echo.New()
+	reg := NewRegistrar(e, "Test API", "0.1.0")
+	noop := func(c echo.Context) error { return c.NoContent(http.StatusOK) }
+
+	reg.GET("/a", noop, Responds(http.StatusOK, struct {
+		ID string `json:"id"`
+	}{}))
+	reg.GET("/b", noop, Responds(http.StatusOK, struct {
+		Token string `json:"token"`
+	}{}))
+
+	aSchema := reg.doc.Paths["/a"]["get"].Responses["200"].Content["application/json"].Schema
+	bSchema := reg.doc.Paths["/b"]["get"].Responses["200"].Content["application/json"].Schema
+
+	assert.Contains(t, aSchema.Properties, "id")
+	assert.Contains(t, bSchema.Properties, "token")
+	assert.NotContains(t, bSchema.Properties, "id")
+}
+
+func TestRegistrarSpecHandlerServesJSON(t *testing.T) {
+	e := echo.New()
+	reg := NewRegistrar(e, "Test API", "0.1.0")
+	reg.GET("/ping", func(c echo.Context) error { return c.NoContent(http.StatusOK) })
+
+	req := httptest.NewRequest(http.MethodGet, "/openapi.json", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	assert.NoError(t, reg.SpecHandler()(c))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"openapi":"3.0.3"`)
+}
@@ -0,0 +1,175 @@
+// Package eventstore provides an append-only, replayable store for domain
+// events backed by PostgreSQL. Aggregates are never updated in place; state
+// is derived by replaying the events recorded for a given aggregate ID.
+package eventstore
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// ErrVersionConflict is returned by Append when the expected version of an
+// aggregate does not match the version currently recorded in the store,
+// i.e. another writer appended events in the meantime.
+var ErrVersionConflict = errors.New("eventstore: version conflict")
+
+// Event represents a domain event to be appended to an aggregate's stream.
+// Version is the position of this event within the aggregate's stream,
+// starting at 1.
+type Event struct {
+	AggregateID   string
+	AggregateType string
+	Version       int
+	EventType     string
+	Payload       []byte
+}
+
+// StoredEvent represents an event as persisted in the store, including the
+// fields assigned by the store itself.
+type StoredEvent struct {
+	ID            int64
+	AggregateID   string
+	AggregateType string
+	Version       int
+	EventType     string
+	Payload       []byte
+	Created       time.Time
+}
+
+// Store represents an append-only event store.
+type Store interface {
+	// Append persists events for an aggregate, rejecting the write with
+	// ErrVersionConflict if expectedVersion does not match the aggregate's
+	// current version.
+	Append(ctx context.Context, aggregateID, aggregateType string, expectedVersion int, events []Event) error
+
+	// Load returns every event recorded for the given aggregate, ordered by
+	// version, oldest first.
+	Load(ctx context.Context, aggregateID string) ([]StoredEvent, error)
+
+	// LoadSince returns up to limit events with an id greater than afterID,
+	// ordered by id, oldest first. It is used by projections to tail the
+	// store without replaying it from the beginning.
+	LoadSince(ctx context.Context, afterID int64, limit int) ([]StoredEvent, error)
+}
+
+// PostgresStore is a Store backed by a PostgreSQL "events" table.
+type PostgresStore struct {
+	db *sql.DB
+}
+
+// NewPostgresStore creates a PostgresStore using the given database handle.
+func NewPostgresStore(db *sql.DB) *PostgresStore {
+	return &PostgresStore{db: db}
+}
+
+// Schema is the DDL for the events table. Callers run it once during
+// provisioning; it is idempotent.
+const Schema = `
+CREATE TABLE IF NOT EXISTS events (
+	id BIGSERIAL PRIMARY KEY,
+	aggregate_id UUID NOT NULL,
+	aggregate_type TEXT NOT NULL,
+	version INT NOT NULL,
+	event_type TEXT NOT NULL,
+	payload JSONB NOT NULL,
+	created TIMESTAMPTZ NOT NULL DEFAULT now(),
+	UNIQUE(aggregate_id, version)
+);
+`
+
+// Migrate creates the events table if it does not already exist.
+func (s *PostgresStore) Migrate(ctx context.Context) error {
+	_, err := s.db.ExecContext(ctx, Schema)
+	return err
+}
+
+// Append implements Store.
+func (s *PostgresStore) Append(ctx context.Context, aggregateID, aggregateType string, expectedVersion int, events []Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var currentVersion int
+	err = tx.QueryRowContext(ctx,
+		"SELECT COALESCE(MAX(version), 0) FROM events WHERE aggregate_id = $1", aggregateID,
+	).Scan(&currentVersion)
+	if err != nil {
+		return err
+	}
+
+	if currentVersion != expectedVersion {
+		return ErrVersionConflict
+	}
+
+	for i, event := range events {
+		version := expectedVersion + i + 1
+		_, err = tx.ExecContext(ctx,
+			`INSERT INTO events(aggregate_id, aggregate_type, version, event_type, payload)
+			 VALUES($1, $2, $3, $4, $5)`,
+			aggregateID, aggregateType, version, event.EventType, event.Payload,
+		)
+		if err != nil {
+			if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+				return ErrVersionConflict
+			}
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// Load implements Store.
+func (s *PostgresStore) Load(ctx context.Context, aggregateID string) ([]StoredEvent, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT id, aggregate_id, aggregate_type, version, event_type, payload, created
+		 FROM events WHERE aggregate_id = $1 ORDER BY version ASC`,
+		aggregateID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanEvents(rows)
+}
+
+// LoadSince implements Store.
+func (s *PostgresStore) LoadSince(ctx context.Context, afterID int64, limit int) ([]StoredEvent, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT id, aggregate_id, aggregate_type, version, event_type, payload, created
+		 FROM events WHERE id > $1 ORDER BY id ASC LIMIT $2`,
+		afterID, limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanEvents(rows)
+}
+
+func scanEvents(rows *sql.Rows) ([]StoredEvent, error) {
+	events := make([]StoredEvent, 0)
+	for rows.Next() {
+		var event StoredEvent
+		err := rows.Scan(&event.ID, &event.AggregateID, &event.AggregateType, &event.Version,
+			&event.EventType, &event.Payload, &event.Created)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+	return events, rows.Err()
+}
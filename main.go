@@ -1,19 +1,34 @@
 package main
 
 import (
+	"context"
 	"database/sql"
+	"embed"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"log"
 	"net/http"
 	"os"
+	"sort"
+	"strings"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
 	_ "github.com/lib/pq"
+
+	"github.com/Rusydy/cqrs-go-implementation/auth"
+	"github.com/Rusydy/cqrs-go-implementation/commandbus"
+	"github.com/Rusydy/cqrs-go-implementation/docs"
+	"github.com/Rusydy/cqrs-go-implementation/eventstore"
+	"github.com/Rusydy/cqrs-go-implementation/user"
 )
 
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
 // Command represents a write operation command.
 type Command interface {
 	Validate() error
@@ -24,36 +39,143 @@ type Query interface {
 	Validate() error
 }
 
-// ArticleWriteModel represents the write model for an article.
+// ArticleAggregateType identifies the article aggregate in the event store.
+const ArticleAggregateType = "article"
+
+// Event types recorded for the article aggregate.
+const (
+	ArticleCreatedEvent = "ArticleCreated"
+	ArticleUpdatedEvent = "ArticleUpdated"
+	ArticleDeletedEvent = "ArticleDeleted"
+)
+
+// ErrArticleDeleted is returned when a command targets an article that has
+// already been deleted.
+var ErrArticleDeleted = errors.New("article has been deleted")
+
+// ErrNotArticleAuthor is returned when a user attempts to update or delete
+// an article they did not create.
+var ErrNotArticleAuthor = errors.New("only the article's author may do this")
+
+// ArticleCreatedPayload is the payload of an ArticleCreatedEvent.
+type ArticleCreatedPayload struct {
+	Author  string    `json:"author"`
+	Title   string    `json:"title"`
+	Body    string    `json:"body"`
+	Slug    string    `json:"slug"`
+	Created time.Time `json:"created"`
+}
+
+// ArticleUpdatedPayload is the payload of an ArticleUpdatedEvent. The
+// author of an article cannot be changed once it is created.
+type ArticleUpdatedPayload struct {
+	Title string `json:"title"`
+	Body  string `json:"body"`
+}
+
+// ArticleDeletedPayload is the payload of an ArticleDeletedEvent. It carries
+// no data; the event type alone is the signal.
+type ArticleDeletedPayload struct{}
+
+// ArticleWriteModel represents the write model for an article, reconstructed
+// by replaying its event stream.
 type ArticleWriteModel struct {
-	ID      int       `json:"id"`
+	ID      string    `json:"id"`
 	Author  string    `json:"author"`
 	Title   string    `json:"title"`
 	Body    string    `json:"body"`
+	Slug    string    `json:"slug"`
 	Created time.Time `json:"created"`
+	Deleted bool      `json:"-"`
+	Version int       `json:"-"`
 }
 
-// ArticleReadModel represents the read model for an article.
+// Apply mutates the aggregate to reflect a single event from its stream.
+func (a *ArticleWriteModel) Apply(event eventstore.StoredEvent) error {
+	switch event.EventType {
+	case ArticleCreatedEvent:
+		var payload ArticleCreatedPayload
+		if err := json.Unmarshal(event.Payload, &payload); err != nil {
+			return err
+		}
+		a.ID = event.AggregateID
+		a.Author = payload.Author
+		a.Title = payload.Title
+		a.Body = payload.Body
+		a.Slug = payload.Slug
+		a.Created = payload.Created
+	case ArticleUpdatedEvent:
+		var payload ArticleUpdatedPayload
+		if err := json.Unmarshal(event.Payload, &payload); err != nil {
+			return err
+		}
+		a.Title = payload.Title
+		a.Body = payload.Body
+	case ArticleDeletedEvent:
+		a.Deleted = true
+	default:
+		return fmt.Errorf("article aggregate: unknown event type %q", event.EventType)
+	}
+
+	a.Version = event.Version
+	return nil
+}
+
+// slugify derives a URL-safe slug from an article title.
+func slugify(title string) string {
+	var b strings.Builder
+	lastHyphen := true
+	for _, r := range strings.ToLower(title) {
+		switch {
+		case r >= 'a' && r <= 'z' || r >= '0' && r <= '9':
+			b.WriteRune(r)
+			lastHyphen = false
+		case !lastHyphen:
+			b.WriteRune('-')
+			lastHyphen = true
+		}
+	}
+	return strings.Trim(b.String(), "-")
+}
+
+// NewArticleFromHistory rebuilds an article aggregate by replaying its
+// recorded events. It returns sql.ErrNoRows if the aggregate has no events.
+func NewArticleFromHistory(events []eventstore.StoredEvent) (*ArticleWriteModel, error) {
+	if len(events) == 0 {
+		return nil, sql.ErrNoRows
+	}
+
+	article := &ArticleWriteModel{}
+	for _, event := range events {
+		if err := article.Apply(event); err != nil {
+			return nil, err
+		}
+	}
+
+	return article, nil
+}
+
+// ArticleReadModel represents the read model for an article. ID is the
+// article's aggregate ID, shared with the write side.
 type ArticleReadModel struct {
-	ID      int    `json:"id"`
+	ID      string `json:"id"`
+	Slug    string `json:"slug"`
 	Author  string `json:"author"`
 	Title   string `json:"title"`
 	Body    string `json:"body"`
 	Created string `json:"created"`
 }
 
-// CreateArticleCommand represents the command for creating an article.
+// CreateArticleCommand represents the command for creating an article. The
+// author is not part of the command payload: it is taken from the
+// authenticated user making the request.
 type CreateArticleCommand struct {
-	Author string `json:"author"`
-	Title  string `json:"title"`
-	Body   string `json:"body"`
+	Title string `json:"title"`
+	Body  string `json:"body"`
 }
 
 // Validate validates the create article command.
 func (c *CreateArticleCommand) Validate() error {
-	if c.Author == "" {
-		return errors.New("author is required")
-	}
 	if c.Title == "" {
 		return errors.New("title is required")
 	}
@@ -63,52 +185,218 @@ func (c *CreateArticleCommand) Validate() error {
 	return nil
 }
 
-// GetArticlesQuery represents the query for retrieving articles.
+// CreateArticleCommandType identifies CreateArticlePayload commands on the
+// command bus.
+const CreateArticleCommandType = "CreateArticle"
+
+// CreateArticlePayload is the command bus payload dispatched for a
+// CreateArticle request. It carries the authenticated author alongside the
+// command so the handler that eventually runs it doesn't need a request
+// context to know who issued it.
+type CreateArticlePayload struct {
+	AuthorID string               `json:"author_id"`
+	Command  CreateArticleCommand `json:"command"`
+}
+
+// defaultArticlesLimit and maxArticlesLimit bound the page size accepted by
+// GetArticlesQuery when the caller does not specify one, or specifies one
+// that is too large to serve cheaply.
+const (
+	defaultArticlesLimit = 20
+	maxArticlesLimit     = 100
+)
+
+// GetArticlesQuery represents the query for retrieving articles, parsed from
+// the `q`, `author`, `limit`, and `offset` URL query parameters.
 type GetArticlesQuery struct {
-	Query  string `json:"query"`
-	Author string `json:"author"`
+	Query  string `query:"q"`
+	Author string `query:"author"`
+	Limit  int    `query:"limit"`
+	Offset int    `query:"offset"`
 }
 
-// Validate validates the get articles query.
+// Validate validates the get articles query, defaulting Limit when it is
+// unset and rejecting negative or oversized values.
 func (q *GetArticlesQuery) Validate() error {
+	if q.Limit == 0 {
+		q.Limit = defaultArticlesLimit
+	}
+	if q.Limit < 0 {
+		return errors.New("limit must not be negative")
+	}
+	if q.Limit > maxArticlesLimit {
+		return fmt.Errorf("limit must not exceed %d", maxArticlesLimit)
+	}
+	if q.Offset < 0 {
+		return errors.New("offset must not be negative")
+	}
 	return nil
 }
 
-// ArticleWriteRepository represents the repository for write operations on articles.
-type ArticleWriteRepository interface {
-	Create(article *ArticleWriteModel) error
+// ArticleSearchResult is the paginated envelope returned by
+// ArticleReadRepository.Search.
+type ArticleSearchResult struct {
+	Items      []ArticleReadModel `json:"items"`
+	Total      int                `json:"total"`
+	NextCursor *int               `json:"next_cursor"`
+}
+
+// UpdateArticleCommand represents the command for updating an article's
+// title and body. The author cannot be changed.
+type UpdateArticleCommand struct {
+	Title string `json:"title"`
+	Body  string `json:"body"`
+}
+
+// Validate validates the update article command.
+func (c *UpdateArticleCommand) Validate() error {
+	if c.Title == "" {
+		return errors.New("title is required")
+	}
+	if c.Body == "" {
+		return errors.New("body is required")
+	}
+	return nil
 }
 
 // ArticleReadRepository represents the repository for read operations on articles.
 type ArticleReadRepository interface {
-	GetAll() ([]ArticleReadModel, error)
+	Search(ctx context.Context, query GetArticlesQuery) (*ArticleSearchResult, error)
+	GetByID(id string) (*ArticleReadModel, error)
+	GetBySlug(slug string) (*ArticleReadModel, error)
 }
 
 // ArticleWriteService represents the service for write operations on articles.
+// Commands are handled by loading the aggregate from its event stream,
+// applying business rules, and appending the resulting events with
+// optimistic concurrency.
 type ArticleWriteService struct {
-	repo ArticleWriteRepository
+	store eventstore.Store
+}
+
+// NewArticleWriteService creates an ArticleWriteService backed by the given
+// event store.
+func NewArticleWriteService(store eventstore.Store) *ArticleWriteService {
+	return &ArticleWriteService{store: store}
 }
 
-// CreateArticle creates a new article.
-func (s *ArticleWriteService) CreateArticle(command *CreateArticleCommand) error {
+// CreateArticle creates a new article authored by authorID and returns its
+// aggregate ID.
+func (s *ArticleWriteService) CreateArticle(ctx context.Context, authorID string, command *CreateArticleCommand) (string, error) {
 	err := command.Validate()
 	if err != nil {
-		return err
+		return "", err
 	}
 
-	article := &ArticleWriteModel{
-		Author:  command.Author,
+	aggregateID := uuid.NewString()
+	// Suffix the slug with a piece of the aggregate ID so two articles with
+	// the same title can't collide; the DB-level UNIQUE constraint is the
+	// backstop.
+	slug := slugify(command.Title) + "-" + aggregateID[:8]
+
+	payload, err := json.Marshal(ArticleCreatedPayload{
+		Author:  authorID,
 		Title:   command.Title,
 		Body:    command.Body,
+		Slug:    slug,
 		Created: time.Now(),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	event := eventstore.Event{
+		AggregateID:   aggregateID,
+		AggregateType: ArticleAggregateType,
+		EventType:     ArticleCreatedEvent,
+		Payload:       payload,
+	}
+
+	// A brand-new aggregate is expected to be at version 0.
+	if err := s.store.Append(ctx, aggregateID, ArticleAggregateType, 0, []eventstore.Event{event}); err != nil {
+		return "", err
+	}
+
+	return aggregateID, nil
+}
+
+// loadArticle replays the event stream for id, returning ErrArticleDeleted if
+// the article has since been deleted.
+func (s *ArticleWriteService) loadArticle(ctx context.Context, id string) (*ArticleWriteModel, error) {
+	events, err := s.store.Load(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	article, err := NewArticleFromHistory(events)
+	if err != nil {
+		return nil, err
+	}
+
+	if article.Deleted {
+		return nil, ErrArticleDeleted
+	}
+
+	return article, nil
+}
+
+// UpdateArticle updates an existing article's title and body. It returns
+// ErrNotArticleAuthor if userID did not create the article.
+func (s *ArticleWriteService) UpdateArticle(ctx context.Context, userID, id string, command *UpdateArticleCommand) error {
+	if err := command.Validate(); err != nil {
+		return err
 	}
 
-	err = s.repo.Create(article)
+	article, err := s.loadArticle(ctx, id)
 	if err != nil {
 		return err
 	}
+	if article.Author != userID {
+		return ErrNotArticleAuthor
+	}
 
-	return nil
+	payload, err := json.Marshal(ArticleUpdatedPayload{
+		Title: command.Title,
+		Body:  command.Body,
+	})
+	if err != nil {
+		return err
+	}
+
+	event := eventstore.Event{
+		AggregateID:   id,
+		AggregateType: ArticleAggregateType,
+		EventType:     ArticleUpdatedEvent,
+		Payload:       payload,
+	}
+
+	return s.store.Append(ctx, id, ArticleAggregateType, article.Version, []eventstore.Event{event})
+}
+
+// DeleteArticle marks an article as deleted. It returns ErrNotArticleAuthor
+// if userID did not create the article.
+func (s *ArticleWriteService) DeleteArticle(ctx context.Context, userID, id string) error {
+	article, err := s.loadArticle(ctx, id)
+	if err != nil {
+		return err
+	}
+	if article.Author != userID {
+		return ErrNotArticleAuthor
+	}
+
+	payload, err := json.Marshal(ArticleDeletedPayload{})
+	if err != nil {
+		return err
+	}
+
+	event := eventstore.Event{
+		AggregateID:   id,
+		AggregateType: ArticleAggregateType,
+		EventType:     ArticleDeletedEvent,
+		Payload:       payload,
+	}
+
+	return s.store.Append(ctx, id, ArticleAggregateType, article.Version, []eventstore.Event{event})
 }
 
 // ArticleReadService represents the service for read operations on articles.
@@ -116,36 +404,30 @@ type ArticleReadService struct {
 	repo ArticleReadRepository
 }
 
-// GetArticles retrieves a list of articles.
-func (s *ArticleReadService) GetArticles(query *GetArticlesQuery) ([]ArticleReadModel, error) {
+// GetArticles retrieves a paginated, optionally filtered and searched list
+// of articles.
+func (s *ArticleReadService) GetArticles(ctx context.Context, query *GetArticlesQuery) (*ArticleSearchResult, error) {
 	err := query.Validate()
 	if err != nil {
 		return nil, err
 	}
 
-	articles, err := s.repo.GetAll()
+	result, err := s.repo.Search(ctx, *query)
 	if err != nil {
 		return nil, err
 	}
 
-	return articles, nil
+	return result, nil
 }
 
-// ArticleWriteRepositoryImpl represents the PostgreSQL repository for write operations on articles.
-type ArticleWriteRepositoryImpl struct {
-	db *sql.DB
+// GetArticleByID retrieves a single article by its aggregate ID.
+func (s *ArticleReadService) GetArticleByID(id string) (*ArticleReadModel, error) {
+	return s.repo.GetByID(id)
 }
 
-// Create creates a new article in the PostgreSQL database.
-func (r *ArticleWriteRepositoryImpl) Create(article *ArticleWriteModel) error {
-	// Perform the create operation on the database
-	_, err := r.db.Exec("INSERT INTO articles(author, title, body, created) VALUES($1, $2, $3, $4)",
-		article.Author, article.Title, article.Body, article.Created)
-	if err != nil {
-		return err
-	}
-
-	return nil
+// GetArticleBySlug retrieves a single article by its slug.
+func (s *ArticleReadService) GetArticleBySlug(slug string) (*ArticleReadModel, error) {
+	return s.repo.GetBySlug(slug)
 }
 
 // ArticleReadRepositoryImpl represents the PostgreSQL repository for read operations on articles.
@@ -153,10 +435,25 @@ type ArticleReadRepositoryImpl struct {
 	db *sql.DB
 }
 
-// GetAll retrieves all articles from the PostgreSQL database.
-func (r *ArticleReadRepositoryImpl) GetAll() ([]ArticleReadModel, error) {
-	// Perform the query operation on the database
-	rows, err := r.db.Query("SELECT id, author, title, body, created FROM articles ORDER BY created DESC")
+// Search retrieves a page of articles from the PostgreSQL database,
+// optionally filtered by author and/or full-text searched across title and
+// body.
+func (r *ArticleReadRepositoryImpl) Search(ctx context.Context, query GetArticlesQuery) (*ArticleSearchResult, error) {
+	where, args := buildArticleSearchFilter(query)
+
+	var total int
+	err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM articles"+where, args...).Scan(&total)
+	if err != nil {
+		return nil, err
+	}
+
+	limitArgs := append(append([]interface{}{}, args...), query.Limit, query.Offset)
+	sqlQuery := fmt.Sprintf(
+		"SELECT aggregate_id, slug, author, title, body, created FROM articles%s ORDER BY created DESC LIMIT $%d OFFSET $%d",
+		where, len(args)+1, len(args)+2,
+	)
+
+	rows, err := r.db.QueryContext(ctx, sqlQuery, limitArgs...)
 	if err != nil {
 		return nil, err
 	}
@@ -165,51 +462,447 @@ func (r *ArticleReadRepositoryImpl) GetAll() ([]ArticleReadModel, error) {
 	articles := make([]ArticleReadModel, 0)
 	for rows.Next() {
 		var article ArticleReadModel
-		err := rows.Scan(&article.ID, &article.Author, &article.Title, &article.Body, &article.Created)
+		err := rows.Scan(&article.ID, &article.Slug, &article.Author, &article.Title, &article.Body, &article.Created)
 		if err != nil {
 			return nil, err
 		}
 
 		articles = append(articles, article)
 	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	var nextCursor *int
+	if query.Offset+len(articles) < total {
+		next := query.Offset + query.Limit
+		nextCursor = &next
+	}
+
+	return &ArticleSearchResult{Items: articles, Total: total, NextCursor: nextCursor}, nil
+}
+
+// buildArticleSearchFilter builds a SQL WHERE clause and its positional
+// arguments for the author and full-text search filters of query. The
+// returned clause is empty when neither filter is set.
+func buildArticleSearchFilter(query GetArticlesQuery) (string, []interface{}) {
+	var clauses []string
+	var args []interface{}
+
+	if query.Query != "" {
+		args = append(args, query.Query)
+		clauses = append(clauses, fmt.Sprintf(
+			"to_tsvector('english', title || ' ' || body) @@ plainto_tsquery($%d)", len(args),
+		))
+	}
+
+	if query.Author != "" {
+		args = append(args, query.Author)
+		clauses = append(clauses, fmt.Sprintf("author = $%d", len(args)))
+	}
+
+	if len(clauses) == 0 {
+		return "", args
+	}
 
-	return articles, nil
+	return " WHERE " + strings.Join(clauses, " AND "), args
+}
+
+// GetByID retrieves a single article by its aggregate ID from the
+// PostgreSQL database, returning sql.ErrNoRows if it does not exist.
+func (r *ArticleReadRepositoryImpl) GetByID(id string) (*ArticleReadModel, error) {
+	return r.get("aggregate_id", id)
+}
+
+// GetBySlug retrieves a single article by its slug from the PostgreSQL
+// database, returning sql.ErrNoRows if it does not exist.
+func (r *ArticleReadRepositoryImpl) GetBySlug(slug string) (*ArticleReadModel, error) {
+	return r.get("slug", slug)
+}
+
+func (r *ArticleReadRepositoryImpl) get(column, value string) (*ArticleReadModel, error) {
+	row := r.db.QueryRow(
+		fmt.Sprintf("SELECT aggregate_id, slug, author, title, body, created FROM articles WHERE %s = $1", column),
+		value,
+	)
+
+	var article ArticleReadModel
+	err := row.Scan(&article.ID, &article.Slug, &article.Author, &article.Title, &article.Body, &article.Created)
+	if err != nil {
+		return nil, err
+	}
+
+	return &article, nil
+}
+
+// articleProjectorCheckpoint is this projector's row name in the
+// projector_checkpoint table.
+const articleProjectorCheckpoint = "articles"
+
+// ArticleProjector rebuilds the read-side articles table by tailing the
+// event store. It keeps the two sides of the CQRS split decoupled: the
+// write side only ever appends events, and the read side is just another
+// consumer of that stream. Its cursor is persisted in the
+// projector_checkpoint table, updated in the same transaction as the
+// projection writes it covers, so a process restart resumes from the last
+// applied event instead of replaying the whole stream into an
+// already-populated table.
+type ArticleProjector struct {
+	store eventstore.Store
+	db    *sql.DB
+}
+
+// NewArticleProjector creates an ArticleProjector.
+func NewArticleProjector(store eventstore.Store, db *sql.DB) *ArticleProjector {
+	return &ArticleProjector{store: store, db: db}
+}
+
+// Tail applies every event recorded since the last call to Tail, updating
+// the read-side articles table and advancing the persisted checkpoint in
+// the same transaction. It returns the number of events applied.
+func (p *ArticleProjector) Tail(ctx context.Context) (int, error) {
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	var cursor int64
+	err = tx.QueryRowContext(ctx,
+		"SELECT cursor FROM projector_checkpoint WHERE name = $1", articleProjectorCheckpoint,
+	).Scan(&cursor)
+	if err != nil {
+		return 0, err
+	}
+
+	events, err := p.store.LoadSince(ctx, cursor, 100)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, event := range events {
+		if err := p.apply(ctx, tx, event); err != nil {
+			return 0, err
+		}
+		cursor = event.ID
+	}
+
+	if len(events) > 0 {
+		if _, err := tx.ExecContext(ctx,
+			"UPDATE projector_checkpoint SET cursor = $1 WHERE name = $2", cursor, articleProjectorCheckpoint,
+		); err != nil {
+			return 0, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+
+	return len(events), nil
+}
+
+func (p *ArticleProjector) apply(ctx context.Context, tx *sql.Tx, event eventstore.StoredEvent) error {
+	if event.AggregateType != ArticleAggregateType {
+		return nil
+	}
+
+	switch event.EventType {
+	case ArticleCreatedEvent:
+		var payload ArticleCreatedPayload
+		if err := json.Unmarshal(event.Payload, &payload); err != nil {
+			return err
+		}
+		_, err := tx.ExecContext(ctx,
+			"INSERT INTO articles(aggregate_id, slug, author, title, body, created) VALUES($1, $2, $3, $4, $5, $6)",
+			event.AggregateID, payload.Slug, payload.Author, payload.Title, payload.Body, payload.Created,
+		)
+		return err
+	case ArticleUpdatedEvent:
+		var payload ArticleUpdatedPayload
+		if err := json.Unmarshal(event.Payload, &payload); err != nil {
+			return err
+		}
+		_, err := tx.ExecContext(ctx,
+			"UPDATE articles SET title = $1, body = $2 WHERE aggregate_id = $3",
+			payload.Title, payload.Body, event.AggregateID,
+		)
+		return err
+	case ArticleDeletedEvent:
+		_, err := tx.ExecContext(ctx, "DELETE FROM articles WHERE aggregate_id = $1", event.AggregateID)
+		return err
+	default:
+		return fmt.Errorf("article projector: unknown event type %q", event.EventType)
+	}
+}
+
+// Rebuild truncates the read-side articles table, resets the persisted
+// checkpoint, and replays every event recorded for the article aggregate
+// from the beginning, leaving the read model consistent with the event
+// store.
+func (p *ArticleProjector) Rebuild(ctx context.Context) error {
+	if _, err := p.db.ExecContext(ctx, "TRUNCATE TABLE articles"); err != nil {
+		return err
+	}
+	if _, err := p.db.ExecContext(ctx,
+		"UPDATE projector_checkpoint SET cursor = 0 WHERE name = $1", articleProjectorCheckpoint,
+	); err != nil {
+		return err
+	}
+
+	for {
+		applied, err := p.Tail(ctx)
+		if err != nil {
+			return err
+		}
+		if applied == 0 {
+			return nil
+		}
+	}
+}
+
+// Run polls the event store for new events every interval, applying them to
+// the read model, until ctx is cancelled.
+func (p *ArticleProjector) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := p.Tail(ctx); err != nil {
+				log.Println("article projector: failed to tail events:", err)
+			}
+		}
+	}
 }
 
 // ArticleHandler represents the HTTP handler for articles.
 type ArticleHandler struct {
 	writeService *ArticleWriteService
 	readService  *ArticleReadService
+	projector    *ArticleProjector
+	bus          commandbus.Bus
 }
 
-// CreateArticle handles the creation of a new article.
+// CreateArticle enqueues the creation of a new article and returns the ID of
+// the command that will perform it. The article itself is created
+// asynchronously; callers poll GET /commands/:id for its outcome.
 func (h *ArticleHandler) CreateArticle(c echo.Context) error {
 	command := new(CreateArticleCommand)
 	if err := c.Bind(command); err != nil {
 		return c.JSON(http.StatusBadRequest, "Invalid request payload")
 	}
+	if err := command.Validate(); err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
 
-	err := h.writeService.CreateArticle(command)
+	payload := CreateArticlePayload{AuthorID: auth.UserID(c), Command: *command}
+	commandID, err := h.bus.Dispatch(c.Request().Context(), CreateArticleCommandType, payload)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, "Failed to create article")
+		return c.JSON(http.StatusInternalServerError, "Failed to enqueue article creation")
 	}
 
-	return c.JSON(http.StatusCreated, "Article created successfully")
+	return c.JSON(http.StatusAccepted, map[string]string{"command_id": string(commandID)})
 }
 
-// GetArticles handles the retrieval of articles.
+// GetArticles handles the retrieval of a paginated, filtered, and
+// optionally full-text searched list of articles.
 func (h *ArticleHandler) GetArticles(c echo.Context) error {
 	query := new(GetArticlesQuery)
 	if err := c.Bind(query); err != nil {
 		return c.JSON(http.StatusBadRequest, "Invalid request query")
 	}
+	if err := query.Validate(); err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
 
-	articles, err := h.readService.GetArticles(query)
+	result, err := h.readService.GetArticles(c.Request().Context(), query)
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, "Failed to retrieve articles")
 	}
 
-	return c.JSON(http.StatusOK, articles)
+	return c.JSON(http.StatusOK, result)
+}
+
+// GetArticleByID handles looking up a single article by its ID.
+func (h *ArticleHandler) GetArticleByID(c echo.Context) error {
+	article, err := h.readService.GetArticleByID(c.Param("id"))
+	if err != nil {
+		return h.respondWithError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, article)
+}
+
+// GetArticleBySlug handles looking up a single article by its slug.
+func (h *ArticleHandler) GetArticleBySlug(c echo.Context) error {
+	article, err := h.readService.GetArticleBySlug(c.Param("slug"))
+	if err != nil {
+		return h.respondWithError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, article)
+}
+
+// UpdateArticle handles updating an existing article.
+func (h *ArticleHandler) UpdateArticle(c echo.Context) error {
+	command := new(UpdateArticleCommand)
+	if err := c.Bind(command); err != nil {
+		return c.JSON(http.StatusBadRequest, "Invalid request payload")
+	}
+	if err := command.Validate(); err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	err := h.writeService.UpdateArticle(c.Request().Context(), auth.UserID(c), c.Param("id"), command)
+	if err != nil {
+		return h.respondWithError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, "Article updated successfully")
+}
+
+// DeleteArticle handles deleting an existing article.
+func (h *ArticleHandler) DeleteArticle(c echo.Context) error {
+	err := h.writeService.DeleteArticle(c.Request().Context(), auth.UserID(c), c.Param("id"))
+	if err != nil {
+		return h.respondWithError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, "Article deleted successfully")
+}
+
+// RebuildReadModel rebuilds the read-side articles table from scratch by
+// replaying every event in the store.
+func (h *ArticleHandler) RebuildReadModel(c echo.Context) error {
+	if err := h.projector.Rebuild(c.Request().Context()); err != nil {
+		return c.JSON(http.StatusInternalServerError, "Failed to rebuild read model")
+	}
+
+	return c.JSON(http.StatusOK, "Read model rebuilt successfully")
+}
+
+// respondWithError maps a write or read repository error to the appropriate
+// HTTP response, translating a missing or deleted article into a 404 and an
+// authorship mismatch into a 403.
+func (h *ArticleHandler) respondWithError(c echo.Context, err error) error {
+	if errors.Is(err, sql.ErrNoRows) || errors.Is(err, ErrArticleDeleted) {
+		return c.JSON(http.StatusNotFound, "Article not found")
+	}
+	if errors.Is(err, ErrNotArticleAuthor) {
+		return c.JSON(http.StatusForbidden, "Only the article's author may do this")
+	}
+	if errors.Is(err, eventstore.ErrVersionConflict) {
+		return c.JSON(http.StatusConflict, "Article was modified by another request, please retry")
+	}
+
+	return c.JSON(http.StatusInternalServerError, "Failed to process request")
+}
+
+// CommandStatusResponse is the response body for GET /commands/:id.
+type CommandStatusResponse struct {
+	ID        string `json:"id"`
+	Type      string `json:"type"`
+	Status    string `json:"status"`
+	Attempts  int    `json:"attempts"`
+	LastError string `json:"last_error,omitempty"`
+}
+
+// CommandHandler represents the HTTP handler for polling the status of
+// commands dispatched through the command bus.
+type CommandHandler struct {
+	bus commandbus.Bus
+}
+
+// GetCommand handles looking up the status of a previously dispatched
+// command.
+func (h *CommandHandler) GetCommand(c echo.Context) error {
+	job, err := h.bus.Status(c.Request().Context(), commandbus.CommandID(c.Param("id")))
+	if errors.Is(err, commandbus.ErrNotFound) {
+		return c.JSON(http.StatusNotFound, "Command not found")
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, "Failed to retrieve command")
+	}
+
+	return c.JSON(http.StatusOK, CommandStatusResponse{
+		ID:        string(job.ID),
+		Type:      job.Type,
+		Status:    string(job.Status),
+		Attempts:  job.Attempts,
+		LastError: job.LastError,
+	})
+}
+
+// UserHandler represents the HTTP handler for account registration and
+// authentication.
+type UserHandler struct {
+	service *user.Service
+}
+
+// Register handles new account registration.
+func (h *UserHandler) Register(c echo.Context) error {
+	command := new(user.RegisterCommand)
+	if err := c.Bind(command); err != nil {
+		return c.JSON(http.StatusBadRequest, "Invalid request payload")
+	}
+
+	id, err := h.service.Register(c.Request().Context(), command)
+	if err != nil {
+		if errors.Is(err, user.ErrUsernameTaken) {
+			return c.JSON(http.StatusConflict, "Username already taken")
+		}
+		return c.JSON(http.StatusInternalServerError, "Failed to register user")
+	}
+
+	return c.JSON(http.StatusCreated, map[string]string{"id": id})
+}
+
+// Login handles authenticating an existing account and issuing it a bearer
+// token.
+func (h *UserHandler) Login(c echo.Context) error {
+	command := new(user.LoginCommand)
+	if err := c.Bind(command); err != nil {
+		return c.JSON(http.StatusBadRequest, "Invalid request payload")
+	}
+
+	token, err := h.service.Login(c.Request().Context(), command)
+	if err != nil {
+		if errors.Is(err, user.ErrInvalidCredentials) {
+			return c.JSON(http.StatusUnauthorized, "Invalid username or password")
+		}
+		return c.JSON(http.StatusInternalServerError, "Failed to log in")
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"token": token})
+}
+
+// runMigrations applies every embedded *.sql file under migrations/, in
+// filename order. Migrations are expected to be idempotent (CREATE TABLE IF
+// NOT EXISTS, etc.) so this is safe to run on every startup.
+func runMigrations(ctx context.Context, db *sql.DB) error {
+	entries, err := migrationFiles.ReadDir("migrations")
+	if err != nil {
+		return err
+	}
+
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+
+	for _, entry := range entries {
+		sqlBytes, err := migrationFiles.ReadFile("migrations/" + entry.Name())
+		if err != nil {
+			return err
+		}
+
+		if _, err := db.ExecContext(ctx, string(sqlBytes)); err != nil {
+			return fmt.Errorf("migration %s: %w", entry.Name(), err)
+		}
+	}
+
+	return nil
 }
 
 func main() {
@@ -220,6 +913,13 @@ func main() {
 	dbName := os.Getenv("DB_NAME")
 	dbPort := os.Getenv("DB_PORT")
 
+	// Retrieve JWT signing configuration from environment variables
+	jwtSecret := os.Getenv("JWT_SECRET")
+	jwtTTL, err := time.ParseDuration(os.Getenv("JWT_TTL"))
+	if err != nil {
+		jwtTTL = 24 * time.Hour
+	}
+
 	// Construct the connection string
 	connStr := fmt.Sprintf("postgres://%s:%s@%s:%s/%s?sslmode=disable", dbUser, dbPass, dbHost, dbPort, dbName)
 
@@ -230,19 +930,62 @@ func main() {
 	}
 	defer db.Close()
 
+	// Set up the event store and migrate its schema
+	store := eventstore.NewPostgresStore(db)
+	if err := store.Migrate(context.Background()); err != nil {
+		log.Fatal(err)
+	}
+
+	// Apply the read-side schema migrations
+	if err := runMigrations(context.Background(), db); err != nil {
+		log.Fatal(err)
+	}
+
 	// Create instances of the repositories
-	writeRepo := &ArticleWriteRepositoryImpl{db: db}
 	readRepo := &ArticleReadRepositoryImpl{db: db}
+	userRepo := user.NewPostgresRepository(db)
 
 	// Create instances of the services
-	writeService := &ArticleWriteService{repo: writeRepo}
+	tokens := auth.NewTokenIssuer(jwtSecret, jwtTTL)
+	writeService := NewArticleWriteService(store)
 	readService := &ArticleReadService{repo: readRepo}
+	userService := user.NewService(userRepo, tokens)
+
+	// Set up the command bus that write operations are dispatched through,
+	// and the worker pool that executes them.
+	bus := commandbus.NewPostgresBus(db)
+	if err := bus.Migrate(context.Background()); err != nil {
+		log.Fatal(err)
+	}
+	bus.Register(CreateArticleCommandType, func(ctx context.Context, payload []byte) error {
+		var p CreateArticlePayload
+		if err := json.Unmarshal(payload, &p); err != nil {
+			return err
+		}
+		_, err := writeService.CreateArticle(ctx, p.AuthorID, &p.Command)
+		return err
+	})
+	worker := commandbus.NewWorker(bus, time.Second)
+	workerCtx, cancelWorker := context.WithCancel(context.Background())
+	defer cancelWorker()
+	go worker.Run(workerCtx)
+
+	// Start the projection runner that keeps the read model in sync with
+	// the event store.
+	projector := NewArticleProjector(store, db)
+	projectorCtx, cancelProjector := context.WithCancel(context.Background())
+	defer cancelProjector()
+	go projector.Run(projectorCtx, time.Second)
 
-	// Create an instance of the article handler
+	// Create instances of the HTTP handlers
 	articleHandler := &ArticleHandler{
 		writeService: writeService,
 		readService:  readService,
+		projector:    projector,
+		bus:          bus,
 	}
+	userHandler := &UserHandler{service: userService}
+	commandHandler := &CommandHandler{bus: bus}
 
 	// Initialize the Echo instance
 	e := echo.New()
@@ -251,9 +994,70 @@ func main() {
 	e.Use(middleware.Logger())
 	e.Use(middleware.Recover())
 
-	// Define the HTTP routes
-	e.POST("/articles", articleHandler.CreateArticle)
-	e.GET("/articles", articleHandler.GetArticles)
+	// Define the HTTP routes, recording each one's request and response
+	// shapes so they can be served back as an OpenAPI specification.
+	reg := docs.NewRegistrar(e, "cqrs-go-implementation API", "1.0.0")
+
+	reg.POST("/register", userHandler.Register,
+		docs.Summary("Register a new account"),
+		docs.Body(user.RegisterCommand{}),
+		docs.Responds(http.StatusCreated, struct {
+			ID string `json:"id"`
+		}{}),
+	)
+	reg.POST("/login", userHandler.Login,
+		docs.Summary("Log in to an existing account"),
+		docs.Body(user.LoginCommand{}),
+		docs.Responds(http.StatusOK, struct {
+			Token string `json:"token"`
+		}{}),
+	)
+
+	reg.GET("/articles", articleHandler.GetArticles,
+		docs.Summary("Search articles"),
+		docs.Query(GetArticlesQuery{}),
+		docs.Responds(http.StatusOK, ArticleSearchResult{}),
+	)
+	reg.GET("/articles/slug/:slug", articleHandler.GetArticleBySlug,
+		docs.Summary("Get an article by slug"),
+		docs.PathParam("slug"),
+		docs.Responds(http.StatusOK, ArticleReadModel{}),
+	)
+	reg.GET("/articles/:id", articleHandler.GetArticleByID,
+		docs.Summary("Get an article by ID"),
+		docs.PathParam("id"),
+		docs.Responds(http.StatusOK, ArticleReadModel{}),
+	)
+	reg.GET("/commands/:id", commandHandler.GetCommand,
+		docs.Summary("Get the status of a dispatched command"),
+		docs.PathParam("id"),
+		docs.Responds(http.StatusOK, CommandStatusResponse{}),
+	)
+
+	// Writes require a valid bearer token
+	articles := reg.Group("/articles", auth.Middleware(tokens))
+	articles.POST("", articleHandler.CreateArticle,
+		docs.Summary("Create an article"),
+		docs.Body(CreateArticleCommand{}),
+		docs.Responds(http.StatusAccepted, struct {
+			CommandID string `json:"command_id"`
+		}{}),
+	)
+	articles.PUT("/:id", articleHandler.UpdateArticle,
+		docs.Summary("Update an article"),
+		docs.PathParam("id"),
+		docs.Body(UpdateArticleCommand{}),
+	)
+	articles.DELETE("/:id", articleHandler.DeleteArticle,
+		docs.Summary("Delete an article"),
+		docs.PathParam("id"),
+	)
+	articles.POST("/rebuild", articleHandler.RebuildReadModel,
+		docs.Summary("Rebuild the read model from the event store"),
+	)
+
+	e.GET("/openapi.json", reg.SpecHandler())
+	e.GET("/docs", reg.UIHandler())
 
 	// Error handling middleware
 	e.HTTPErrorHandler = func(err error, c echo.Context) {
@@ -1,6 +1,8 @@
 package main
 
 import (
+	"context"
+	"database/sql"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
@@ -10,40 +12,109 @@ import (
 
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
+
+	"github.com/Rusydy/cqrs-go-implementation/commandbus"
+	"github.com/Rusydy/cqrs-go-implementation/eventstore"
 )
 
-// MockWriteRepository represents a mock repository for write operations on articles.
-type MockWriteRepository struct {
-	articles []*ArticleWriteModel
+// MockEventStore represents an in-memory eventstore.Store for tests.
+type MockEventStore struct {
+	events      []eventstore.Event
+	byAggregate map[string][]eventstore.Event
+	// appendErr, when set, is returned by Append instead of appending,
+	// so tests can simulate conditions like eventstore.ErrVersionConflict.
+	appendErr error
 }
 
-// Create adds a new article to the repository.
-func (r *MockWriteRepository) Create(article *ArticleWriteModel) error {
-	r.articles = append(r.articles, article)
+// Append adds events to the in-memory store, ignoring concurrency checks.
+func (s *MockEventStore) Append(_ context.Context, aggregateID, _ string, _ int, events []eventstore.Event) error {
+	if s.appendErr != nil {
+		return s.appendErr
+	}
+	s.events = append(s.events, events...)
+	if s.byAggregate == nil {
+		s.byAggregate = make(map[string][]eventstore.Event)
+	}
+	s.byAggregate[aggregateID] = append(s.byAggregate[aggregateID], events...)
 	return nil
 }
 
+// Load returns the events seeded or appended for the given aggregate.
+func (s *MockEventStore) Load(_ context.Context, aggregateID string) ([]eventstore.StoredEvent, error) {
+	stored := make([]eventstore.StoredEvent, len(s.byAggregate[aggregateID]))
+	for i, event := range s.byAggregate[aggregateID] {
+		stored[i] = eventstore.StoredEvent{
+			AggregateID:   event.AggregateID,
+			AggregateType: event.AggregateType,
+			Version:       event.Version,
+			EventType:     event.EventType,
+			Payload:       event.Payload,
+		}
+	}
+	return stored, nil
+}
+
+// LoadSince is unused by these tests.
+func (s *MockEventStore) LoadSince(_ context.Context, _ int64, _ int) ([]eventstore.StoredEvent, error) {
+	return nil, nil
+}
+
 // MockReadRepository represents a mock repository for read operations on articles.
 type MockReadRepository struct {
 	articles []*ArticleReadModel
 }
 
-// GetAll retrieves all articles from the repository.
-func (r *MockReadRepository) GetAll() ([]ArticleReadModel, error) {
+// Search returns every article in the repository, ignoring filters and
+// pagination.
+func (r *MockReadRepository) Search(_ context.Context, _ GetArticlesQuery) (*ArticleSearchResult, error) {
 	articles := make([]ArticleReadModel, len(r.articles))
 	for i, article := range r.articles {
 		articles[i] = *article
 	}
-	return articles, nil
+	return &ArticleSearchResult{Items: articles, Total: len(articles)}, nil
+}
+
+// GetByID retrieves a single article by ID, or sql.ErrNoRows if absent.
+func (r *MockReadRepository) GetByID(id string) (*ArticleReadModel, error) {
+	for _, article := range r.articles {
+		if article.ID == id {
+			return article, nil
+		}
+	}
+	return nil, sql.ErrNoRows
+}
+
+// GetBySlug retrieves a single article by slug, or sql.ErrNoRows if absent.
+func (r *MockReadRepository) GetBySlug(slug string) (*ArticleReadModel, error) {
+	for _, article := range r.articles {
+		if article.Slug == slug {
+			return article, nil
+		}
+	}
+	return nil, sql.ErrNoRows
 }
 
 func TestCreateArticleHandler(t *testing.T) {
-	// Create a mock write repository
-	mockWriteRepo := &MockWriteRepository{}
+	// Create a mock event store
+	mockStore := &MockEventStore{}
+	writeService := NewArticleWriteService(mockStore)
 
-	// Create an article handler with the mock repository
+	// The command bus executes CreateArticle synchronously in tests, so the
+	// event store can be inspected immediately after the handler returns.
+	bus := commandbus.NewSyncBus()
+	bus.Register(CreateArticleCommandType, func(ctx context.Context, payload []byte) error {
+		var p CreateArticlePayload
+		if err := json.Unmarshal(payload, &p); err != nil {
+			return err
+		}
+		_, err := writeService.CreateArticle(ctx, p.AuthorID, &p.Command)
+		return err
+	})
+
+	// Create an article handler with the mock store
 	articleHandler := &ArticleHandler{
-		writeService: &ArticleWriteService{repo: mockWriteRepo},
+		writeService: writeService,
+		bus:          bus,
 	}
 
 	// Create a new Echo instance
@@ -51,13 +122,13 @@ func TestCreateArticleHandler(t *testing.T) {
 
 	// Create a POST request with a JSON payload
 	req := httptest.NewRequest(http.MethodPost, "/articles", strings.NewReader(`{
-		"author": "John Doe",
 		"title": "Hello World",
 		"body": "This is the article body"
 	}`))
 	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
 	rec := httptest.NewRecorder()
 	c := e.NewContext(req, rec)
+	c.Set("userID", "john-doe")
 
 	// Invoke the CreateArticle handler
 	err := articleHandler.CreateArticle(c)
@@ -65,14 +136,19 @@ func TestCreateArticleHandler(t *testing.T) {
 	// Assert that no error occurred
 	assert.NoError(t, err)
 
-	// Assert that the response code is HTTP 201 Created
-	assert.Equal(t, http.StatusCreated, rec.Code)
+	// Assert that the command was accepted for asynchronous processing
+	assert.Equal(t, http.StatusAccepted, rec.Code)
 
-	// Assert that the article was added to the repository
-	assert.Equal(t, 1, len(mockWriteRepo.articles))
-	assert.Equal(t, "John Doe", mockWriteRepo.articles[0].Author)
-	assert.Equal(t, "Hello World", mockWriteRepo.articles[0].Title)
-	assert.Equal(t, "This is the article body", mockWriteRepo.articles[0].Body)
+	// Assert that an ArticleCreated event was appended to the store
+	assert.Equal(t, 1, len(mockStore.events))
+	assert.Equal(t, ArticleCreatedEvent, mockStore.events[0].EventType)
+
+	var payload ArticleCreatedPayload
+	err = json.Unmarshal(mockStore.events[0].Payload, &payload)
+	assert.NoError(t, err)
+	assert.Equal(t, "john-doe", payload.Author)
+	assert.Equal(t, "Hello World", payload.Title)
+	assert.Equal(t, "This is the article body", payload.Body)
 }
 
 func TestGetArticlesHandler(t *testing.T) {
@@ -80,7 +156,8 @@ func TestGetArticlesHandler(t *testing.T) {
 	mockReadRepo := &MockReadRepository{
 		articles: []*ArticleReadModel{
 			{
-				ID:     1,
+				ID:     "11111111-1111-1111-1111-111111111111",
+				Slug:   "hello-world-11111111",
 				Author: "John Doe",
 				Title:  "Hello World",
 				Body:   "This is the article body",
@@ -88,7 +165,8 @@ func TestGetArticlesHandler(t *testing.T) {
 				Created: time.Now().String(),
 			},
 			{
-				ID:      2,
+				ID:      "22222222-2222-2222-2222-222222222222",
+				Slug:    "greetings-22222222",
 				Author:  "Jane Smith",
 				Title:   "Greetings",
 				Body:    "Welcome to the world",
@@ -120,18 +198,203 @@ func TestGetArticlesHandler(t *testing.T) {
 	assert.Equal(t, http.StatusOK, rec.Code)
 
 	// Parse the response body
-	var response []ArticleReadModel
+	var response ArticleSearchResult
 	err = json.Unmarshal(rec.Body.Bytes(), &response)
 
 	// Assert that the response body was parsed successfully
 	assert.NoError(t, err)
 
 	// Assert that the correct number of articles were returned
-	assert.Equal(t, len(mockReadRepo.articles), len(response))
+	assert.Equal(t, len(mockReadRepo.articles), response.Total)
+	assert.Equal(t, len(mockReadRepo.articles), len(response.Items))
 
 	// Assert the contents of the first article
-	assert.Equal(t, mockReadRepo.articles[0].ID, response[0].ID)
-	assert.Equal(t, mockReadRepo.articles[0].Author, response[0].Author)
-	assert.Equal(t, mockReadRepo.articles[0].Title, response[0].Title)
-	assert.Equal(t, mockReadRepo.articles[0].Body, response[0].Body)
+	assert.Equal(t, mockReadRepo.articles[0].ID, response.Items[0].ID)
+	assert.Equal(t, mockReadRepo.articles[0].Author, response.Items[0].Author)
+	assert.Equal(t, mockReadRepo.articles[0].Title, response.Items[0].Title)
+	assert.Equal(t, mockReadRepo.articles[0].Body, response.Items[0].Body)
+}
+
+func TestGetArticlesHandlerInvalidQueryReturnsBadRequest(t *testing.T) {
+	articleHandler := &ArticleHandler{readService: &ArticleReadService{repo: &MockReadRepository{}}}
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/articles?limit=-1", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	err := articleHandler.GetArticles(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestGetArticlesQueryValidate(t *testing.T) {
+	query := &GetArticlesQuery{}
+	assert.NoError(t, query.Validate())
+	assert.Equal(t, defaultArticlesLimit, query.Limit)
+
+	assert.Error(t, (&GetArticlesQuery{Limit: -1}).Validate())
+	assert.Error(t, (&GetArticlesQuery{Limit: maxArticlesLimit + 1}).Validate())
+	assert.Error(t, (&GetArticlesQuery{Offset: -1}).Validate())
+}
+
+func TestBuildArticleSearchFilter(t *testing.T) {
+	where, args := buildArticleSearchFilter(GetArticlesQuery{})
+	assert.Empty(t, where)
+	assert.Empty(t, args)
+
+	where, args = buildArticleSearchFilter(GetArticlesQuery{Query: "golang", Author: "Jane"})
+	assert.Equal(t, " WHERE to_tsvector('english', title || ' ' || body) @@ plainto_tsquery($1) AND author = $2", where)
+	assert.Equal(t, []interface{}{"golang", "Jane"}, args)
+}
+
+func TestGetArticleByIDHandler(t *testing.T) {
+	mockReadRepo := &MockReadRepository{
+		articles: []*ArticleReadModel{
+			{ID: "11111111-1111-1111-1111-111111111111", Author: "John Doe", Title: "Hello World", Body: "Body"},
+		},
+	}
+	articleHandler := &ArticleHandler{readService: &ArticleReadService{repo: mockReadRepo}}
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/articles/11111111-1111-1111-1111-111111111111", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("11111111-1111-1111-1111-111111111111")
+
+	err := articleHandler.GetArticleByID(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var response ArticleReadModel
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Equal(t, "John Doe", response.Author)
+}
+
+func TestGetArticleByIDHandlerNotFound(t *testing.T) {
+	articleHandler := &ArticleHandler{readService: &ArticleReadService{repo: &MockReadRepository{}}}
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/articles/does-not-exist", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("does-not-exist")
+
+	err := articleHandler.GetArticleByID(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestUpdateArticleHandler(t *testing.T) {
+	mockStore := &MockEventStore{}
+	articleHandler := &ArticleHandler{writeService: NewArticleWriteService(mockStore)}
+
+	// Seed the store with an existing article so the update has something
+	// to load and apply optimistic concurrency against.
+	createdPayload, _ := json.Marshal(ArticleCreatedPayload{Author: "john-doe", Title: "Hello World", Body: "Body"})
+	mockStore.events = []eventstore.Event{
+		{AggregateID: "a1", AggregateType: ArticleAggregateType, Version: 1, EventType: ArticleCreatedEvent, Payload: createdPayload},
+	}
+	mockStore.byAggregate = map[string][]eventstore.Event{"a1": mockStore.events}
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPut, "/articles/a1", strings.NewReader(`{
+		"title": "Updated Title",
+		"body": "Updated body"
+	}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("a1")
+	c.Set("userID", "john-doe")
+
+	err := articleHandler.UpdateArticle(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, 2, len(mockStore.events))
+	assert.Equal(t, ArticleUpdatedEvent, mockStore.events[1].EventType)
+}
+
+func TestUpdateArticleHandlerInvalidPayloadReturnsBadRequest(t *testing.T) {
+	mockStore := &MockEventStore{}
+	articleHandler := &ArticleHandler{writeService: NewArticleWriteService(mockStore)}
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPut, "/articles/a1", strings.NewReader(`{
+		"title": "",
+		"body": ""
+	}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("a1")
+	c.Set("userID", "john-doe")
+
+	err := articleHandler.UpdateArticle(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestUpdateArticleHandlerVersionConflictReturnsConflict(t *testing.T) {
+	mockStore := &MockEventStore{}
+	articleHandler := &ArticleHandler{writeService: NewArticleWriteService(mockStore)}
+
+	createdPayload, _ := json.Marshal(ArticleCreatedPayload{Author: "john-doe", Title: "Hello World", Body: "Body"})
+	mockStore.events = []eventstore.Event{
+		{AggregateID: "a1", AggregateType: ArticleAggregateType, Version: 1, EventType: ArticleCreatedEvent, Payload: createdPayload},
+	}
+	mockStore.byAggregate = map[string][]eventstore.Event{"a1": mockStore.events}
+	mockStore.appendErr = eventstore.ErrVersionConflict
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPut, "/articles/a1", strings.NewReader(`{
+		"title": "Updated Title",
+		"body": "Updated body"
+	}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("a1")
+	c.Set("userID", "john-doe")
+
+	err := articleHandler.UpdateArticle(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusConflict, rec.Code)
+}
+
+func TestDeleteArticleHandler(t *testing.T) {
+	mockStore := &MockEventStore{}
+	articleHandler := &ArticleHandler{writeService: NewArticleWriteService(mockStore)}
+
+	createdPayload, _ := json.Marshal(ArticleCreatedPayload{Author: "john-doe", Title: "Hello World", Body: "Body"})
+	mockStore.events = []eventstore.Event{
+		{AggregateID: "a1", AggregateType: ArticleAggregateType, Version: 1, EventType: ArticleCreatedEvent, Payload: createdPayload},
+	}
+	mockStore.byAggregate = map[string][]eventstore.Event{"a1": mockStore.events}
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodDelete, "/articles/a1", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("a1")
+	c.Set("userID", "john-doe")
+
+	err := articleHandler.DeleteArticle(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, 2, len(mockStore.events))
+	assert.Equal(t, ArticleDeletedEvent, mockStore.events[1].EventType)
 }
@@ -0,0 +1,168 @@
+// Package user handles account registration and authentication: it owns
+// the users table, hashes passwords, and issues bearer tokens on
+// successful login via the auth package.
+package user
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/Rusydy/cqrs-go-implementation/auth"
+)
+
+// ErrInvalidCredentials is returned by Service.Login when the username does
+// not exist or the password does not match.
+var ErrInvalidCredentials = errors.New("user: invalid username or password")
+
+// ErrUsernameTaken is returned by Service.Register when the username is
+// already registered.
+var ErrUsernameTaken = errors.New("user: username already taken")
+
+// User represents a registered account.
+type User struct {
+	ID           string
+	Username     string
+	PasswordHash string
+	Created      time.Time
+}
+
+// RegisterCommand represents the command for registering a new account.
+type RegisterCommand struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+// Validate validates the register command.
+func (c *RegisterCommand) Validate() error {
+	if c.Username == "" {
+		return errors.New("username is required")
+	}
+	if len(c.Password) < 8 {
+		return errors.New("password must be at least 8 characters")
+	}
+	return nil
+}
+
+// LoginCommand represents the command for logging into an existing account.
+type LoginCommand struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+// Validate validates the login command.
+func (c *LoginCommand) Validate() error {
+	if c.Username == "" {
+		return errors.New("username is required")
+	}
+	if c.Password == "" {
+		return errors.New("password is required")
+	}
+	return nil
+}
+
+// Repository represents the repository for user accounts.
+type Repository interface {
+	Create(ctx context.Context, user *User) error
+	GetByUsername(ctx context.Context, username string) (*User, error)
+}
+
+// PostgresRepository is a Repository backed by a PostgreSQL "users" table.
+type PostgresRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresRepository creates a PostgresRepository using the given
+// database handle.
+func NewPostgresRepository(db *sql.DB) *PostgresRepository {
+	return &PostgresRepository{db: db}
+}
+
+// Create inserts a new user, returning ErrUsernameTaken if the username is
+// already registered.
+func (r *PostgresRepository) Create(ctx context.Context, user *User) error {
+	_, err := r.db.ExecContext(ctx,
+		"INSERT INTO users(id, username, password_hash, created) VALUES($1, $2, $3, $4)",
+		user.ID, user.Username, user.PasswordHash, user.Created,
+	)
+	if err != nil && isUniqueViolation(err) {
+		return ErrUsernameTaken
+	}
+	return err
+}
+
+// GetByUsername retrieves a user by username, returning sql.ErrNoRows if it
+// does not exist.
+func (r *PostgresRepository) GetByUsername(ctx context.Context, username string) (*User, error) {
+	var u User
+	err := r.db.QueryRowContext(ctx,
+		"SELECT id, username, password_hash, created FROM users WHERE username = $1", username,
+	).Scan(&u.ID, &u.Username, &u.PasswordHash, &u.Created)
+	if err != nil {
+		return nil, err
+	}
+	return &u, nil
+}
+
+// Service represents the service for account registration and
+// authentication.
+type Service struct {
+	repo   Repository
+	tokens *auth.TokenIssuer
+}
+
+// NewService creates a Service backed by the given repository and token
+// issuer.
+func NewService(repo Repository, tokens *auth.TokenIssuer) *Service {
+	return &Service{repo: repo, tokens: tokens}
+}
+
+// Register creates a new account and returns its user ID.
+func (s *Service) Register(ctx context.Context, command *RegisterCommand) (string, error) {
+	if err := command.Validate(); err != nil {
+		return "", err
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(command.Password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", err
+	}
+
+	u := &User{
+		ID:           uuid.NewString(),
+		Username:     command.Username,
+		PasswordHash: string(hash),
+		Created:      time.Now(),
+	}
+
+	if err := s.repo.Create(ctx, u); err != nil {
+		return "", err
+	}
+
+	return u.ID, nil
+}
+
+// Login verifies a username and password and returns a signed bearer token.
+func (s *Service) Login(ctx context.Context, command *LoginCommand) (string, error) {
+	if err := command.Validate(); err != nil {
+		return "", err
+	}
+
+	u, err := s.repo.GetByUsername(ctx, command.Username)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", ErrInvalidCredentials
+	}
+	if err != nil {
+		return "", err
+	}
+
+	if bcrypt.CompareHashAndPassword([]byte(u.PasswordHash), []byte(command.Password)) != nil {
+		return "", ErrInvalidCredentials
+	}
+
+	return s.tokens.Issue(u.ID)
+}
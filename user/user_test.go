@@ -0,0 +1,71 @@
+package user
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Rusydy/cqrs-go-implementation/auth"
+)
+
+// mockRepository is an in-memory Repository for tests.
+type mockRepository struct {
+	byUsername map[string]*User
+}
+
+func (r *mockRepository) Create(_ context.Context, u *User) error {
+	if r.byUsername == nil {
+		r.byUsername = make(map[string]*User)
+	}
+	if _, exists := r.byUsername[u.Username]; exists {
+		return ErrUsernameTaken
+	}
+	r.byUsername[u.Username] = u
+	return nil
+}
+
+func (r *mockRepository) GetByUsername(_ context.Context, username string) (*User, error) {
+	u, ok := r.byUsername[username]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	return u, nil
+}
+
+func TestServiceRegisterAndLogin(t *testing.T) {
+	repo := &mockRepository{}
+	service := NewService(repo, auth.NewTokenIssuer("test-secret", time.Hour))
+
+	id, err := service.Register(context.Background(), &RegisterCommand{Username: "jane", Password: "hunter22"})
+	assert.NoError(t, err)
+	assert.NotEmpty(t, id)
+
+	token, err := service.Login(context.Background(), &LoginCommand{Username: "jane", Password: "hunter22"})
+	assert.NoError(t, err)
+	assert.NotEmpty(t, token)
+}
+
+func TestServiceLoginWrongPassword(t *testing.T) {
+	repo := &mockRepository{}
+	service := NewService(repo, auth.NewTokenIssuer("test-secret", time.Hour))
+
+	_, err := service.Register(context.Background(), &RegisterCommand{Username: "jane", Password: "hunter22"})
+	assert.NoError(t, err)
+
+	_, err = service.Login(context.Background(), &LoginCommand{Username: "jane", Password: "wrong-password"})
+	assert.ErrorIs(t, err, ErrInvalidCredentials)
+}
+
+func TestServiceRegisterDuplicateUsername(t *testing.T) {
+	repo := &mockRepository{}
+	service := NewService(repo, auth.NewTokenIssuer("test-secret", time.Hour))
+
+	_, err := service.Register(context.Background(), &RegisterCommand{Username: "jane", Password: "hunter22"})
+	assert.NoError(t, err)
+
+	_, err = service.Register(context.Background(), &RegisterCommand{Username: "jane", Password: "hunter22"})
+	assert.ErrorIs(t, err, ErrUsernameTaken)
+}